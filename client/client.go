@@ -0,0 +1,266 @@
+// Package client is a thin wrapper around net/http that adds sane defaults
+// (timeouts, retries) and a handful of convenience methods for the JSON and
+// form-encoded requests used throughout the module's services.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vipernet-xyz/utils-go/logger"
+)
+
+const (
+	defaultRetries = 3
+	defaultTimeout = 10 * time.Second
+)
+
+// CustomClientOpts configures a Client built with NewCustomClientWithOptions.
+type CustomClientOpts struct {
+	Retries   int
+	Timeout   time.Duration
+	Transport http.RoundTripper
+
+	// RequestLogger, when set, is invoked once per call to DoRequestWithRetries
+	// with the outcome of the (possibly retried) request. Use NewSlogRequestLogger
+	// to route these through a *logger.Logger.
+	RequestLogger func(RequestLogEntry)
+
+	// RetryPolicy decides whether and how long to wait between attempts. If
+	// nil, a default ExponentialBackoff is used.
+	RetryPolicy RetryPolicy
+}
+
+// Client is an HTTP client with a bounded number of retries on network errors
+// and 5xx responses.
+type Client struct {
+	httpClient    *http.Client
+	retries       int
+	requestLogger func(RequestLogEntry)
+	retryPolicy   RetryPolicy
+}
+
+// NewDefaultClient returns a Client with the module's default retry count and
+// timeout.
+func NewDefaultClient() *Client {
+	return NewCustomClient(defaultRetries, defaultTimeout)
+}
+
+// NewCustomClient returns a Client with the given retry count and timeout.
+func NewCustomClient(retries int, timeout time.Duration) *Client {
+	return NewCustomClientWithOptions(CustomClientOpts{
+		Retries: retries,
+		Timeout: timeout,
+	})
+}
+
+// NewCustomClientWithOptions returns a Client configured by opts. Any extra
+// functional options (such as WithRequestDump) are applied to opts before the
+// Client is built.
+func NewCustomClientWithOptions(opts CustomClientOpts, extraOpts ...func(*CustomClientOpts)) *Client {
+	for _, opt := range extraOpts {
+		opt(&opts)
+	}
+
+	retryPolicy := opts.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy
+	}
+
+	return &Client{
+		// Transport is passed through as-is, including nil: http.Client
+		// resolves a nil Transport to http.DefaultTransport lazily, per
+		// request. Resolving it eagerly here would bake in whatever
+		// http.DefaultTransport happened to be at construction time, which
+		// breaks the httpmock.Activate()-after-construction pattern used
+		// throughout this package's tests.
+		httpClient: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: opts.Transport,
+		},
+		retries:       opts.Retries,
+		requestLogger: opts.RequestLogger,
+		retryPolicy:   retryPolicy,
+	}
+}
+
+// DoRequestWithRetries sends req, consulting c's RetryPolicy after each
+// attempt to decide whether to retry and how long to wait beforehand, up to
+// c.retries retries after the first attempt. The request body, if any, must be replayable
+// (req.GetBody set, as done automatically by http.NewRequest for common body
+// types) so it can be resent on each attempt. Waiting between attempts
+// respects req's context, so cancellation aborts a pending backoff.
+func (c *Client) DoRequestWithRetries(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	var resp *http.Response
+	var err error
+	attempt := 0
+
+	for {
+		if attempt > 0 && req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				c.logRequest(req, nil, attempt, time.Since(start), bodyErr)
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
+		resp, err = c.httpClient.Do(req)
+
+		if attempt >= c.retries {
+			break
+		}
+
+		retry, delay := c.retryPolicy.ShouldRetry(attempt, resp, err)
+		if !retry {
+			break
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		if sleepErr := sleepWithContext(req.Context(), delay); sleepErr != nil {
+			c.logRequest(req, nil, attempt, time.Since(start), sleepErr)
+			return nil, sleepErr
+		}
+
+		attempt++
+	}
+
+	c.logRequest(req, resp, attempt, time.Since(start), err)
+
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+// forwardRequestID propagates the request id carried on ctx (set by the
+// handler package via logger.ContextWithRequestID) onto the outbound
+// request's RequestIDHeader, so downstream services can be traced under the
+// same id. It never overwrites a header the caller already set explicitly.
+func forwardRequestID(ctx context.Context, header http.Header) {
+	if header.Get(logger.RequestIDHeader) != "" {
+		return
+	}
+	if id := logger.RequestID(ctx); id != "" {
+		header.Set(logger.RequestIDHeader, id)
+	}
+}
+
+func (c *Client) logRequest(req *http.Request, resp *http.Response, retryCount int, duration time.Duration, err error) {
+	if c.requestLogger == nil {
+		return
+	}
+
+	entry := RequestLogEntry{
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		RetryCount: retryCount,
+		Duration:   duration,
+		Err:        err,
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+	}
+
+	c.requestLogger(entry)
+}
+
+// PostWithURLJSONParams marshals params as a JSON body and POSTs it to url.
+func (c *Client) PostWithURLJSONParams(url string, params map[string]string, header http.Header) (*http.Response, error) {
+	return c.PostWithURLJSONParamsWithCtx(context.Background(), url, params, header)
+}
+
+// PostWithURLJSONParamsWithCtx is PostWithURLJSONParams with a caller-supplied context.
+func (c *Client) PostWithURLJSONParamsWithCtx(ctx context.Context, url string, params map[string]string, header http.Header) (*http.Response, error) {
+	return c.doJSONWithCtx(ctx, http.MethodPost, url, params, header)
+}
+
+// PutWithURLJSONParams marshals params as a JSON body and PUTs it to url.
+func (c *Client) PutWithURLJSONParams(url string, params map[string]string, header http.Header) (*http.Response, error) {
+	return c.PutWithURLJSONParamsWithCtx(context.Background(), url, params, header)
+}
+
+// PutWithURLJSONParamsWithCtx is PutWithURLJSONParams with a caller-supplied context.
+func (c *Client) PutWithURLJSONParamsWithCtx(ctx context.Context, url string, params map[string]string, header http.Header) (*http.Response, error) {
+	return c.doJSONWithCtx(ctx, http.MethodPut, url, params, header)
+}
+
+func (c *Client) doJSONWithCtx(ctx context.Context, method string, rawURL string, params map[string]string, header http.Header) (*http.Response, error) {
+	var body io.Reader
+	if params != nil {
+		encoded, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	if params != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	forwardRequestID(ctx, req.Header)
+
+	return c.DoRequestWithRetries(req)
+}
+
+// PostWithURLEncodedParams POSTs params as an application/x-www-form-urlencoded body.
+func (c *Client) PostWithURLEncodedParams(rawURL string, params url.Values, header http.Header) (*http.Response, error) {
+	return c.PostWithURLEncodedParamsWithCtx(context.Background(), rawURL, params, header)
+}
+
+// PostWithURLEncodedParamsWithCtx is PostWithURLEncodedParams with a caller-supplied context.
+func (c *Client) PostWithURLEncodedParamsWithCtx(ctx context.Context, rawURL string, params url.Values, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader([]byte(params.Encode())))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	forwardRequestID(ctx, req.Header)
+
+	return c.DoRequestWithRetries(req)
+}
+
+// GetWithURLAndParams issues a GET request with params appended to the URL's query string.
+func (c *Client) GetWithURLAndParams(rawURL string, params url.Values, header http.Header) (*http.Response, error) {
+	return c.GetWithURLAndParamsWithCtx(context.Background(), rawURL, params, header)
+}
+
+// GetWithURLAndParamsWithCtx is GetWithURLAndParams with a caller-supplied context.
+func (c *Client) GetWithURLAndParamsWithCtx(ctx context.Context, rawURL string, params url.Values, header http.Header) (*http.Response, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	parsed.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header
+	forwardRequestID(ctx, req.Header)
+
+	return c.DoRequestWithRetries(req)
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"sync"
+)
+
+// dumpSeparator is written between each request/response exchange in the dump file.
+const dumpSeparator = "\n--------------------------------------------------------------------------------\n"
+
+// WithRequestDump returns a CustomClientOpts functional option that wraps the
+// configured Transport so every request/response pair is appended to the file
+// at path, mirroring httputil.DumpRequest/DumpResponse output. Bodies are
+// truncated at maxBodyBytes; the response body is always fully readable by
+// the caller regardless of the truncation applied to the dump.
+func WithRequestDump(path string, maxBodyBytes int) func(*CustomClientOpts) {
+	return func(opts *CustomClientOpts) {
+		// opts.Transport is passed through as-is (including nil) and
+		// resolved lazily in RoundTrip, for the same reason
+		// NewCustomClientWithOptions doesn't resolve it eagerly: baking in
+		// http.DefaultTransport here would capture whatever it was at
+		// option-application time, ahead of any later httpmock.Activate().
+		opts.Transport = newDumpingTransport(opts.Transport, path, maxBodyBytes)
+	}
+}
+
+// dumpingTransport is an http.RoundTripper that logs a dump of every request
+// and response it handles to a file before returning the response to the caller.
+type dumpingTransport struct {
+	next         http.RoundTripper
+	maxBodyBytes int
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newDumpingTransport(next http.RoundTripper, path string, maxBodyBytes int) *dumpingTransport {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		// Dumping is a debugging aid; a bad path shouldn't prevent the
+		// client from making requests.
+		file = nil
+	}
+
+	return &dumpingTransport{next: next, maxBodyBytes: maxBodyBytes, file: file}
+}
+
+// transport resolves the wrapped RoundTripper, falling back to
+// http.DefaultTransport lazily (not at construction time) if none was set.
+func (t *dumpingTransport) transport() http.RoundTripper {
+	if t.next == nil {
+		return http.DefaultTransport
+	}
+	return t.next
+}
+
+func (t *dumpingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqDump, err := dumpRequestCapped(req, t.maxBodyBytes)
+	if err != nil {
+		reqDump = []byte(fmt.Sprintf("failed to dump request: %v", err))
+	}
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		t.write(reqDump, []byte(fmt.Sprintf("error: %v", err)))
+		return resp, err
+	}
+
+	respDump, dumpErr := dumpResponseCapped(resp, t.maxBodyBytes)
+	if dumpErr != nil {
+		respDump = []byte(fmt.Sprintf("failed to dump response: %v", dumpErr))
+	}
+
+	t.write(reqDump, respDump)
+
+	return resp, nil
+}
+
+func (t *dumpingTransport) write(reqDump, respDump []byte) {
+	if t.file == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, _ = t.file.Write(reqDump)
+	_, _ = t.file.WriteString("\n")
+	_, _ = t.file.Write(respDump)
+	_, _ = t.file.WriteString(dumpSeparator)
+}
+
+// captureBody reads up to max bytes of body into memory and returns a
+// ReadCloser that replays those bytes followed by whatever remains unread of
+// body, so the original content is fully preserved for the caller while the
+// captured prefix can be used for a bounded dump.
+func captureBody(body io.ReadCloser, max int) (captured []byte, restored io.ReadCloser, err error) {
+	if body == nil {
+		return nil, nil, nil
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(body, int64(max))); err != nil {
+		return nil, nil, err
+	}
+
+	captured = buf.Bytes()
+	restored = readCloser{io.MultiReader(bytes.NewReader(captured), body), body}
+
+	return captured, restored, nil
+}
+
+// readCloser pairs a Reader (a replay buffer chained to the still-open
+// original stream) with the original body's Close method.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func dumpRequestCapped(req *http.Request, max int) ([]byte, error) {
+	if req.Body == nil {
+		return httputil.DumpRequest(req, true)
+	}
+
+	captured, restored, err := captureBody(req.Body, max)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(captured))
+	dump, dumpErr := httputil.DumpRequest(req, true)
+	req.Body = restored
+
+	return dump, dumpErr
+}
+
+func dumpResponseCapped(resp *http.Response, max int) ([]byte, error) {
+	if resp.Body == nil {
+		return httputil.DumpResponse(resp, false)
+	}
+
+	captured, restored, err := captureBody(resp.Body, max)
+	if err != nil {
+		return nil, err
+	}
+
+	// DumpResponse refuses to dump a body shorter than Content-Length, which
+	// is exactly what happens once the body is truncated for the dump; swap
+	// in the captured length for the duration of the call.
+	resp.Body = io.NopCloser(bytes.NewReader(captured))
+	originalContentLength := resp.ContentLength
+	resp.ContentLength = int64(len(captured))
+	dump, dumpErr := httputil.DumpResponse(resp, true)
+	resp.ContentLength = originalContentLength
+	resp.Body = restored
+
+	return dump, dumpErr
+}
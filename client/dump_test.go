@@ -0,0 +1,114 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestDump_PreservesStreamedResponseBody(t *testing.T) {
+	c := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("the full response body"))
+	}))
+	defer server.Close()
+
+	dumpFile, err := os.CreateTemp(t.TempDir(), "dump-*.log")
+	c.NoError(err)
+	c.NoError(dumpFile.Close())
+
+	client := NewCustomClientWithOptions(CustomClientOpts{Retries: 0}, WithRequestDump(dumpFile.Name(), 1024))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	c.NoError(err)
+
+	resp, err := client.DoRequestWithRetries(req)
+	c.NoError(err)
+
+	body, err := io.ReadAll(resp.Body)
+	c.NoError(err)
+	c.NoError(resp.Body.Close())
+	c.Equal("the full response body", string(body))
+}
+
+func TestWithRequestDump_TruncatesDumpedBodyAtMaxBytes(t *testing.T) {
+	c := require.New(t)
+
+	const fullBody = "0123456789abcdefghij"
+	const maxBodyBytes = 5
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fullBody))
+	}))
+	defer server.Close()
+
+	dumpFile, err := os.CreateTemp(t.TempDir(), "dump-*.log")
+	c.NoError(err)
+	c.NoError(dumpFile.Close())
+
+	client := NewCustomClientWithOptions(CustomClientOpts{Retries: 0}, WithRequestDump(dumpFile.Name(), maxBodyBytes))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	c.NoError(err)
+
+	resp, err := client.DoRequestWithRetries(req)
+	c.NoError(err)
+	c.NoError(resp.Body.Close())
+
+	dumped, err := os.ReadFile(dumpFile.Name())
+	c.NoError(err)
+
+	c.True(strings.Contains(string(dumped), fullBody[:maxBodyBytes]))
+	c.False(strings.Contains(string(dumped), fullBody))
+}
+
+func TestCaptureBody_ReplaysFullContentRegardlessOfCapLimit(t *testing.T) {
+	c := require.New(t)
+
+	const content = "hello world"
+
+	captured, restored, err := captureBody(io.NopCloser(strings.NewReader(content)), 4)
+	c.NoError(err)
+	c.Equal("hell", string(captured))
+
+	replayed, err := io.ReadAll(restored)
+	c.NoError(err)
+	c.NoError(restored.Close())
+	c.Equal(content, string(replayed))
+}
+
+func TestCaptureBody_NilBody(t *testing.T) {
+	c := require.New(t)
+
+	captured, restored, err := captureBody(nil, 10)
+	c.NoError(err)
+	c.Nil(captured)
+	c.Nil(restored)
+}
+
+func TestDumpingTransport_FallsBackToDefaultTransportWhenNilNext(t *testing.T) {
+	c := require.New(t)
+
+	transport := newDumpingTransport(nil, tempDumpPath(t), 1024)
+	c.Equal(http.DefaultTransport, transport.transport())
+
+	custom := &http.Transport{}
+	transport = newDumpingTransport(custom, tempDumpPath(t), 1024)
+	c.Equal(http.RoundTripper(custom), transport.transport())
+}
+
+func tempDumpPath(t *testing.T) string {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "dump-*.log")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	return f.Name()
+}
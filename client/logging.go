@@ -0,0 +1,45 @@
+package client
+
+import (
+	"time"
+
+	"github.com/vipernet-xyz/utils-go/logger"
+)
+
+// RequestLogEntry describes the outcome of a single (possibly retried) call
+// made through Client.DoRequestWithRetries.
+type RequestLogEntry struct {
+	Method     string
+	URL        string
+	Status     int
+	Duration   time.Duration
+	RetryCount int
+	Err        error
+}
+
+// NewSlogRequestLogger returns a CustomClientOpts.RequestLogger hook that
+// records each request as a structured log entry on l. It consults
+// logger.APILogsEnabled on every call so operators can toggle per-request
+// logging at runtime via logger.NewAdminHandler without rebuilding the client.
+func NewSlogRequestLogger(l *logger.Logger) func(RequestLogEntry) {
+	return func(entry RequestLogEntry) {
+		if !logger.APILogsEnabled.Load() {
+			return
+		}
+
+		args := []any{
+			"method", entry.Method,
+			"url", entry.URL,
+			"status", entry.Status,
+			"duration_ms", entry.Duration.Milliseconds(),
+			"retries", entry.RetryCount,
+		}
+		if entry.Err != nil {
+			args = append(args, "error", entry.Err.Error())
+			l.Error("http request failed", args...)
+			return
+		}
+
+		l.Info("http request", args...)
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vipernet-xyz/utils-go/logger"
+)
+
+// waitForLogs polls readLogs until it returns at least n entries or the
+// timeout elapses, since NewTestLogger decodes log output asynchronously.
+func waitForLogs(t *testing.T, readLogs func() []string, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if logs := readLogs(); len(logs) >= n {
+			return logs
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return readLogs()
+}
+
+func TestNewSlogRequestLogger_RespectsAPILogsEnabled(t *testing.T) {
+	c := require.New(t)
+
+	wasEnabled := logger.APILogsEnabled.Load()
+	defer logger.APILogsEnabled.Store(wasEnabled)
+
+	l, readLogs, cleanup := logger.NewTestLogger()
+	defer cleanup()
+
+	requestLogger := NewSlogRequestLogger(l)
+
+	logger.APILogsEnabled.Store(false)
+	requestLogger(RequestLogEntry{Method: "GET", URL: "https://dummy.com", Status: 200})
+	c.Empty(readLogs())
+
+	logger.APILogsEnabled.Store(true)
+	requestLogger(RequestLogEntry{Method: "GET", URL: "https://dummy.com", Status: 200, Duration: time.Second, RetryCount: 1})
+
+	logs := waitForLogs(t, readLogs, 1)
+	c.Len(logs, 1)
+	c.Equal("http request", logs[0])
+}
+
+func TestNewSlogRequestLogger_LogsErrorEntryAsError(t *testing.T) {
+	c := require.New(t)
+
+	wasEnabled := logger.APILogsEnabled.Load()
+	defer logger.APILogsEnabled.Store(wasEnabled)
+	logger.APILogsEnabled.Store(true)
+
+	l, readLogs, cleanup := logger.NewTestLogger()
+	defer cleanup()
+
+	requestLogger := NewSlogRequestLogger(l)
+	requestLogger(RequestLogEntry{Method: "GET", URL: "https://dummy.com", Err: errors.New("boom")})
+
+	logs := waitForLogs(t, readLogs, 1)
+	c.Len(logs, 1)
+	c.Equal("http request failed", logs[0])
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether Client.DoRequestWithRetries should retry after
+// an attempt, and how long to wait before doing so. attempt is 0-indexed: it
+// is the index of the attempt that just completed, not the next one.
+type RetryPolicy interface {
+	ShouldRetry(attempt int, resp *http.Response, err error) (retry bool, delay time.Duration)
+}
+
+// ExponentialBackoff is the default RetryPolicy. It retries on network errors
+// and 5xx/429 responses, waiting min(Max, Base*2^attempt) with full jitter,
+// honoring a Retry-After response header when present.
+type ExponentialBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter func(n int64) int64 // defaults to rand.Int63n; overridable for tests
+}
+
+// DefaultRetryPolicy is used by NewCustomClientWithOptions when
+// CustomClientOpts.RetryPolicy is nil.
+var DefaultRetryPolicy RetryPolicy = ExponentialBackoff{
+	Base: 200 * time.Millisecond,
+	Max:  5 * time.Second,
+}
+
+// ShouldRetry implements RetryPolicy.
+func (b ExponentialBackoff) ShouldRetry(attempt int, resp *http.Response, err error) (bool, time.Duration) {
+	if err == nil && resp != nil && resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests {
+		return false, 0
+	}
+
+	if delay, ok := retryAfterDelay(resp); ok {
+		return true, delay
+	}
+
+	return true, b.backoff(attempt)
+}
+
+func (b ExponentialBackoff) backoff(attempt int) time.Duration {
+	delay := b.Max
+	if shifted := b.Base << uint(attempt); shifted > 0 && shifted < b.Max {
+		delay = shifted
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	jitter := b.Jitter
+	if jitter == nil {
+		jitter = rand.Int63n
+	}
+
+	return time.Duration(jitter(int64(delay)))
+}
+
+// retryAfterDelay parses resp's Retry-After header, which per RFC 9110 is
+// either a number of seconds or an HTTP-date.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// sleepWithContext waits for d, returning early with ctx.Err() if ctx is done first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
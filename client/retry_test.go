@@ -0,0 +1,104 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// closeCountingTransport wraps a RoundTripper and counts how many response
+// bodies it hands back have been closed.
+type closeCountingTransport struct {
+	next   http.RoundTripper
+	closes *int32
+}
+
+func (t *closeCountingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if resp != nil {
+		resp.Body = countingBody{ReadCloser: resp.Body, closes: t.closes}
+	}
+	return resp, err
+}
+
+type countingBody struct {
+	io.ReadCloser
+	closes *int32
+}
+
+func (b countingBody) Close() error {
+	atomic.AddInt32(b.closes, 1)
+	return b.ReadCloser.Close()
+}
+
+func TestClient_DoRequestWithRetries_ClosesBodyOnExhaustedRetries(t *testing.T) {
+	c := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var closes int32
+	transport := &closeCountingTransport{next: http.DefaultTransport, closes: &closes}
+
+	client := NewCustomClientWithOptions(CustomClientOpts{
+		Retries:   2,
+		Timeout:   2 * time.Second,
+		Transport: transport,
+		RetryPolicy: ExponentialBackoff{
+			Base: time.Millisecond,
+			Max:  5 * time.Millisecond,
+		},
+	})
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	c.NoError(err)
+
+	resp, err := client.DoRequestWithRetries(req)
+	c.Error(err)
+	c.Nil(resp)
+
+	// 3 attempts total (1 initial + 2 retries): every response body must be closed.
+	c.Equal(int32(3), atomic.LoadInt32(&closes))
+}
+
+func TestExponentialBackoff_HonorsRetryAfterSeconds(t *testing.T) {
+	c := require.New(t)
+
+	policy := ExponentialBackoff{Base: time.Second, Max: time.Minute}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"2"}}}
+
+	retry, delay := policy.ShouldRetry(0, resp, nil)
+	c.True(retry)
+	c.Equal(2*time.Second, delay)
+}
+
+func TestExponentialBackoff_NoRetryOnSuccess(t *testing.T) {
+	c := require.New(t)
+
+	policy := ExponentialBackoff{Base: time.Millisecond, Max: time.Second}
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	retry, delay := policy.ShouldRetry(0, resp, nil)
+	c.False(retry)
+	c.Zero(delay)
+}
+
+func TestExponentialBackoff_JitterBoundedByBackoff(t *testing.T) {
+	c := require.New(t)
+
+	policy := ExponentialBackoff{Base: 10 * time.Millisecond, Max: 100 * time.Millisecond}
+	resp := &http.Response{StatusCode: http.StatusInternalServerError, Header: http.Header{}}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		_, delay := policy.ShouldRetry(attempt, resp, nil)
+		c.True(delay >= 0)
+		c.True(delay <= policy.Max)
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	"github.com/vipernet-xyz/utils-go/mock-client"
+)
+
+// TestClient_ConstructedBeforeActivate guards against resolving a nil
+// CustomClientOpts.Transport eagerly: httpmock.Activate() swaps
+// http.DefaultTransport after this client is built, and the client must still
+// route through the mock.
+func TestClient_ConstructedBeforeActivate(t *testing.T) {
+	c := require.New(t)
+
+	client := NewDefaultClient()
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mock.AddMockedResponse(http.MethodGet, "https://dummy.com", http.StatusOK, `{"ok":true}`)
+
+	response, err := client.GetWithURLAndParams("https://dummy.com", nil, http.Header{})
+	c.NoError(err)
+	c.Equal(http.StatusOK, response.StatusCode)
+	c.NoError(response.Body.Close())
+}
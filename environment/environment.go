@@ -0,0 +1,14 @@
+// Package environment provides small helpers for reading configuration out
+// of process environment variables with a default fallback.
+package environment
+
+import "os"
+
+// GetString returns the value of the environment variable named key, or
+// fallback if it is unset or empty.
+func GetString(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
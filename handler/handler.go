@@ -0,0 +1,194 @@
+// Package handler provides a small generic framework for HTTP handlers that
+// decode a typed request, run business logic against it, and write a
+// canonical JSON envelope back to the caller.
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/vipernet-xyz/utils-go/logger"
+	"github.com/vipernet-xyz/utils-go/tiny_errors"
+)
+
+// envelope is the canonical JSON response shape written by New.
+type envelope struct {
+	Data      any    `json:"data,omitempty"`
+	Error     any    `json:"error,omitempty"`
+	RequestID string `json:"request_id"`
+}
+
+// New decodes an HTTP request into ReqT, invokes fn with a context carrying a
+// request-scoped *logger.Logger and request id, and writes the result (or
+// error) to w as a JSON envelope of the form {data, error, request_id}.
+//
+// The request id is read from the inbound X-Request-ID header, or generated
+// if absent, and echoed back on the response so callers can correlate it with
+// downstream logs.
+func New[ReqT, RespT any](w http.ResponseWriter, r *http.Request, log *logger.Logger, fn func(ctx context.Context, req ReqT) (RespT, tiny_errors.Error)) {
+	requestID := r.Header.Get(logger.RequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	w.Header().Set(logger.RequestIDHeader, requestID)
+
+	ctx := logger.ContextWithRequestID(r.Context(), requestID)
+	ctx = logger.NewContext(ctx, log.With("request_id", requestID))
+
+	req, decodeErr := decode[ReqT](r)
+	if decodeErr != nil {
+		writeEnvelope(w, http.StatusBadRequest, nil, decodeErr.Error(), requestID)
+		return
+	}
+
+	resp, err := fn(ctx, req)
+	if err != nil {
+		writeEnvelope(w, err.HTTPStatus(), nil, err, requestID)
+		return
+	}
+
+	writeEnvelope(w, http.StatusOK, resp, nil, requestID)
+}
+
+// decode populates a ReqT from the request's JSON body, then overlays mux
+// path variables and query parameters, so path/query values can supply
+// fields the body omits. Overlaid values are converted to the target
+// field's underlying type (int, bool, float, string, ...) rather than
+// always written as JSON strings, so e.g. an int ID field populated from a
+// path variable decodes correctly.
+func decode[ReqT any](r *http.Request) (ReqT, error) {
+	var req ReqT
+
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+			return req, err
+		}
+	}
+
+	overrides := map[string]string{}
+	for k, v := range mux.Vars(r) {
+		overrides[k] = v
+	}
+	for k := range r.URL.Query() {
+		overrides[k] = r.URL.Query().Get(k)
+	}
+
+	if len(overrides) == 0 {
+		return req, nil
+	}
+
+	if err := applyOverrides(&req, overrides); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// applyOverrides sets each field of the struct pointed to by dst whose JSON
+// tag (or field name, case-insensitively) matches a key in values, parsing
+// the string value into the field's Go type. Fields with no matching key are
+// left untouched; dst that isn't a struct pointer is a no-op.
+func applyOverrides(dst any, values map[string]string) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	lowerValues := make(map[string]string, len(values))
+	for k, val := range values {
+		lowerValues[strings.ToLower(k)] = val
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" { // unexported
+			continue
+		}
+
+		name := jsonFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		raw, ok := values[name]
+		if !ok {
+			raw, ok = lowerValues[strings.ToLower(name)]
+		}
+		if !ok {
+			continue
+		}
+
+		if err := setFieldFromString(v.Field(i), raw); err != nil {
+			return fmt.Errorf("decode field %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return field.Name
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func setFieldFromString(field reflect.Value, raw string) error {
+	if !field.CanSet() {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %s for overlay value %q", field.Kind(), raw)
+	}
+
+	return nil
+}
+
+func writeEnvelope(w http.ResponseWriter, status int, data any, errValue any, requestID string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(envelope{Data: data, Error: errValue, RequestID: requestID})
+}
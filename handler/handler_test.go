@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"github.com/vipernet-xyz/utils-go/logger"
+	"github.com/vipernet-xyz/utils-go/tiny_errors"
+)
+
+type itemRequest struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type itemResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestNew_DecodesIntPathVariable(t *testing.T) {
+	c := require.New(t)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		New(w, r, logger.NewNop(), func(ctx context.Context, req itemRequest) (itemResponse, tiny_errors.Error) {
+			return itemResponse{ID: req.ID, Name: req.Name}, nil
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42?name=widget", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	c.Equal(http.StatusOK, rec.Code)
+	c.NotEmpty(rec.Header().Get(logger.RequestIDHeader))
+
+	var body envelope
+	c.NoError(json.NewDecoder(rec.Body).Decode(&body))
+
+	data, err := json.Marshal(body.Data)
+	c.NoError(err)
+
+	var resp itemResponse
+	c.NoError(json.Unmarshal(data, &resp))
+	c.Equal(42, resp.ID)
+	c.Equal("widget", resp.Name)
+}
+
+func TestNew_WritesErrorEnvelopeWithErrorStatus(t *testing.T) {
+	c := require.New(t)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		New(w, r, logger.NewNop(), func(ctx context.Context, req itemRequest) (itemResponse, tiny_errors.Error) {
+			return itemResponse{}, tiny_errors.New("not_found", "item not found", http.StatusNotFound)
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	c.Equal(http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// loglevelPayload is the JSON body accepted/returned by the /admin/loglevel route.
+type loglevelPayload struct {
+	Level string `json:"level"`
+}
+
+// apilogsPayload is the JSON body accepted/returned by the /admin/apilogs route.
+type apilogsPayload struct {
+	Enabled bool `json:"enabled"`
+}
+
+// NewAdminHandler returns an http.Handler that exposes runtime controls for a
+// running service: GET/POST /admin/loglevel reads or atomically changes l's
+// minimum log level, and GET/POST /admin/apilogs reads or flips the
+// process-wide APILogsEnabled toggle consulted by the client package's
+// request logging middleware. It is meant to be mounted under an operator-only
+// path (e.g. behind auth or on a private port), not exposed publicly.
+func NewAdminHandler(l *Logger) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/admin/loglevel", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, loglevelPayload{Level: l.LogLevel()})
+		case http.MethodPost:
+			var payload loglevelPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if err := l.SetLevel(payload.Level); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, loglevelPayload{Level: l.LogLevel()})
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/admin/apilogs", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, apilogsPayload{Enabled: APILogsEnabled.Load()})
+		case http.MethodPost:
+			var payload apilogsPayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			APILogsEnabled.Store(payload.Enabled)
+			writeJSON(w, http.StatusOK, apilogsPayload{Enabled: APILogsEnabled.Load()})
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	return mux
+}
+
+// writeJSON encodes v as the JSON response body and sets the status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
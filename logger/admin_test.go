@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdminHandler_LogLevel(t *testing.T) {
+	c := require.New(t)
+
+	l := New()
+	admin := NewAdminHandler(l)
+
+	body := `{"level":"debug"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/loglevel", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	admin.ServeHTTP(rec, req)
+
+	c.Equal(http.StatusOK, rec.Code)
+	c.Equal("debug", l.LogLevel())
+
+	rec = httptest.NewRecorder()
+	admin.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/loglevel", nil))
+
+	var payload loglevelPayload
+	c.NoError(json.NewDecoder(rec.Body).Decode(&payload))
+	c.Equal("debug", payload.Level)
+}
+
+func TestNewAdminHandler_LogLevel_Invalid(t *testing.T) {
+	c := require.New(t)
+
+	l := New()
+	admin := NewAdminHandler(l)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/loglevel", strings.NewReader(`{"level":"noisy"}`))
+	rec := httptest.NewRecorder()
+	admin.ServeHTTP(rec, req)
+
+	c.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func TestNewAdminHandler_APILogs(t *testing.T) {
+	c := require.New(t)
+
+	l := New()
+	admin := NewAdminHandler(l)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/apilogs", strings.NewReader(`{"enabled":true}`))
+	rec := httptest.NewRecorder()
+	admin.ServeHTTP(rec, req)
+
+	c.Equal(http.StatusOK, rec.Code)
+	c.True(APILogsEnabled.Load())
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/apilogs", strings.NewReader(`{"enabled":false}`))
+	rec = httptest.NewRecorder()
+	admin.ServeHTTP(rec, req)
+	c.False(APILogsEnabled.Load())
+}
+
+// TestSetLevel_ConcurrentWithLogLevel guards against the data race between an
+// admin-handler goroutine calling SetLevel and other goroutines reading
+// LogLevel/Named concurrently.
+func TestSetLevel_ConcurrentWithLogLevel(t *testing.T) {
+	l := New()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			_ = l.SetLevel("debug")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			_ = l.LogLevel()
+			_ = l.Named("client")
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,41 @@
+package logger
+
+import "context"
+
+// RequestIDHeader is the HTTP header used to propagate a request id across
+// service boundaries. The handler package sets it on inbound responses and
+// client.Client forwards it on outbound *WithCtx calls.
+const RequestIDHeader = "X-Request-ID"
+
+type contextKey int
+
+const (
+	loggerContextKey contextKey = iota
+	requestIDContextKey
+)
+
+// NewContext returns a copy of ctx carrying l, retrievable with FromContext.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, l)
+}
+
+// FromContext returns the Logger stashed in ctx by NewContext, or a no-op
+// Logger if ctx carries none.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerContextKey).(*Logger); ok {
+		return l
+	}
+	return NewNop()
+}
+
+// ContextWithRequestID returns a copy of ctx carrying id, retrievable with RequestID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// RequestID returns the request id stashed in ctx by ContextWithRequestID, or
+// the empty string if ctx carries none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
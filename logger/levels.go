@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLevel returns a child Logger whose effective minimum level is level,
+// independent of the parent's: records below it are dropped even if the
+// parent logger (or a level change made via SetLevel/the admin handler) would
+// have let them through.
+func (l *Logger) WithLevel(level string) *Logger {
+	levelVar := logLevelStr(level)
+	if !levelVar.isValid() {
+		levelVar = l.loadLevel()
+	}
+
+	childLevel := new(slog.LevelVar)
+	childLevel.Set(logLevelMap[levelVar])
+
+	child := &Logger{
+		Logger:       slog.New(&levelFilterHandler{next: l.Handler(), level: childLevel}),
+		logHandler:   l.logHandler,
+		programLevel: childLevel,
+		moduleLevels: l.moduleLevels,
+	}
+	child.logLevel.Store(levelVar)
+
+	return child
+}
+
+// NewWithModuleLevels creates a Logger that derives per-subsystem child
+// loggers via Named, each filtered to the level given in moduleLevels (if
+// any), so libraries can embed the logger without forcing their own
+// verbosity onto the host application.
+func NewWithModuleLevels(moduleLevels map[string]string) *Logger {
+	l := New()
+	l.moduleLevels = moduleLevels
+	return l
+}
+
+// Named returns a child Logger tagged with a "module" attribute and, if name
+// has an entry in the moduleLevels passed to NewWithModuleLevels, filtered to
+// that level independently of the parent.
+func (l *Logger) Named(name string) *Logger {
+	child := &Logger{
+		Logger:       l.Logger.With("module", name),
+		logHandler:   l.logHandler,
+		programLevel: l.programLevel,
+		moduleLevels: l.moduleLevels,
+	}
+	child.logLevel.Store(l.loadLevel())
+
+	if level, ok := l.moduleLevels[name]; ok {
+		return child.WithLevel(level)
+	}
+
+	return child
+}
+
+// NewNop returns a Logger whose handler drops every record, for library
+// callers that need a non-nil Logger but don't want to emit output (e.g. in
+// tests).
+func NewNop() *Logger {
+	l := &Logger{
+		Logger:       slog.New(nopHandler{}),
+		logHandler:   logHandlerJSON,
+		programLevel: new(slog.LevelVar),
+	}
+	l.logLevel.Store(logLevelInfo)
+
+	return l
+}
+
+// levelFilterHandler wraps a slog.Handler with its own minimum level,
+// discarding records below it regardless of what the wrapped handler would
+// otherwise accept.
+type levelFilterHandler struct {
+	next  slog.Handler
+	level *slog.LevelVar
+}
+
+func (h *levelFilterHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	// Deliberately ignores h.next.Enabled: next is the parent's handler,
+	// which gates on the parent's own (possibly shared, possibly later
+	// changed) level. Consulting it here would make this handler's
+	// effective level the more restrictive of the two, defeating the
+	// purpose of WithLevel/Named when the child should be more verbose
+	// than its parent.
+	return level >= h.level.Level()
+}
+
+func (h *levelFilterHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *levelFilterHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelFilterHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelFilterHandler) WithGroup(name string) slog.Handler {
+	return &levelFilterHandler{next: h.next.WithGroup(name), level: h.level}
+}
+
+// nopHandler is a slog.Handler that discards every record.
+type nopHandler struct{}
+
+func (nopHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (nopHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h nopHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h nopHandler) WithGroup(string) slog.Handler            { return h }
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLevel_IndependentOfParent(t *testing.T) {
+	c := require.New(t)
+
+	parent := New()
+	c.NoError(parent.SetLevel("error"))
+
+	child := parent.WithLevel("debug")
+	c.Equal("debug", child.LogLevel())
+	c.True(child.Handler().Enabled(nil, logLevelMap[logLevelDebug]))
+
+	// Changing the parent's level afterwards must not affect the child.
+	c.NoError(parent.SetLevel("warn"))
+	c.Equal("debug", child.LogLevel())
+	c.True(child.Handler().Enabled(nil, logLevelMap[logLevelDebug]))
+}
+
+func TestWithLevel_InvalidFallsBackToParent(t *testing.T) {
+	c := require.New(t)
+
+	parent := New()
+	c.NoError(parent.SetLevel("warn"))
+
+	child := parent.WithLevel("noisy")
+	c.Equal("warn", child.LogLevel())
+}
+
+func TestNamed_AppliesModuleLevel(t *testing.T) {
+	c := require.New(t)
+
+	l := NewWithModuleLevels(map[string]string{"client": "error"})
+	c.NoError(l.SetLevel("debug"))
+
+	clientLogger := l.Named("client")
+	c.False(clientLogger.Handler().Enabled(nil, logLevelMap[logLevelWarn]))
+	c.True(clientLogger.Handler().Enabled(nil, logLevelMap[logLevelError]))
+
+	otherLogger := l.Named("other")
+	c.Equal("debug", otherLogger.LogLevel())
+}
+
+func TestNewNop_DropsRecords(t *testing.T) {
+	c := require.New(t)
+
+	l := NewNop()
+	c.False(l.Handler().Enabled(nil, logLevelMap[logLevelError]))
+}
@@ -3,12 +3,14 @@ package logger
 import (
 	"bufio"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log"
 	"log/slog"
 	"os"
 	"regexp"
 	"sync"
+	"sync/atomic"
 
 	"github.com/vipernet-xyz/utils-go/environment"
 )
@@ -42,14 +44,22 @@ var logLevelMap = map[logLevelStr]slog.Level{
 type (
 	Logger struct {
 		*slog.Logger
-		logLevel   logLevelStr
-		logHandler logHandlerStr
+		logLevel     atomic.Value // stores logLevelStr; written by SetLevel, read by LogLevel
+		logHandler   logHandlerStr
+		programLevel *slog.LevelVar
+		moduleLevels map[string]string
 	}
 
 	logLevelStr   string
 	logHandlerStr string
 )
 
+// APILogsEnabled controls, process-wide, whether the client package's request
+// logging middleware emits a structured log record for every outbound HTTP
+// call. It defaults to disabled and is meant to be flipped at runtime via the
+// admin handler returned by NewAdminHandler, without restarting the process.
+var APILogsEnabled atomic.Bool
+
 // isValid checks if a log level string is a valid log level.
 func (l logLevelStr) isValid() bool {
 	switch l {
@@ -106,12 +116,34 @@ func New() *Logger {
 	logLevel := logLevelMap[logLevelVar]
 	programLevel.Set(logLevel)
 
-	return &Logger{Logger: slogger, logLevel: logLevelVar, logHandler: logHandlerVar}
+	l := &Logger{Logger: slogger, logHandler: logHandlerVar, programLevel: programLevel}
+	l.logLevel.Store(logLevelVar)
+
+	return l
 }
 
 // LogLevel returns the current log level as a string.
 func (l *Logger) LogLevel() string {
-	return string(l.logLevel)
+	return string(l.loadLevel())
+}
+
+func (l *Logger) loadLevel() logLevelStr {
+	v, _ := l.logLevel.Load().(logLevelStr)
+	return v
+}
+
+// SetLevel atomically changes the logger's minimum level without restarting
+// the process. It is safe to call concurrently with in-flight logging calls.
+func (l *Logger) SetLevel(level string) error {
+	levelVar := logLevelStr(level)
+	if !levelVar.isValid() {
+		return fmt.Errorf("invalid log level: %s", level)
+	}
+
+	l.programLevel.Set(logLevelMap[levelVar])
+	l.logLevel.Store(levelVar)
+
+	return nil
 }
 
 // LogHandler returns the current log handler as a string.
@@ -119,6 +151,23 @@ func (l *Logger) LogHandler() string {
 	return string(l.logHandler)
 }
 
+// With returns a child Logger that appends args to every subsequent record,
+// preserving the receiver's level and handler configuration. It shadows the
+// embedded *slog.Logger.With, which returns a *slog.Logger, so callers that
+// need a *Logger (e.g. to stash in a context.Context via logger.NewContext)
+// get one back.
+func (l *Logger) With(args ...any) *Logger {
+	child := &Logger{
+		Logger:       l.Logger.With(args...),
+		logHandler:   l.logHandler,
+		programLevel: l.programLevel,
+		moduleLevels: l.moduleLevels,
+	}
+	child.logLevel.Store(l.loadLevel())
+
+	return child
+}
+
 // Fatal logs an Error level log and exits the program using os.Exit(1).
 func (l *Logger) Fatal(msg string, args ...any) {
 	l.Error(msg, args...)
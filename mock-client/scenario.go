@@ -0,0 +1,111 @@
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// Scenario is a fluent builder for a sequence of mocked responses registered
+// against a single method + regex URL pattern. Unlike AddMockedResponse, it
+// can assert on each inbound request and step through a fixed sequence of
+// responses instead of always returning the same one.
+type Scenario struct {
+	method  string
+	pattern *regexp.Regexp
+
+	mu         sync.Mutex
+	steps      []scenarioStep
+	assertions []func(*http.Request) error
+	calls      int
+}
+
+type scenarioStep struct {
+	status int
+	body   string
+}
+
+// NewScenario starts a Scenario matching method against URLs matching the
+// urlPattern regular expression.
+func NewScenario(method, urlPattern string) *Scenario {
+	return &Scenario{method: method, pattern: regexp.MustCompile(urlPattern)}
+}
+
+// Respond appends a response to the scenario's sequence. The first call sets
+// the response returned for the first matching request.
+func (s *Scenario) Respond(status int, body string) *Scenario {
+	s.steps = append(s.steps, scenarioStep{status: status, body: body})
+	return s
+}
+
+// Then appends another response to the sequence, returned on the next
+// matching request after the previous step has been consumed.
+func (s *Scenario) Then(status int, body string) *Scenario {
+	return s.Respond(status, body)
+}
+
+// OnRequest registers an assertion run against every request the scenario
+// matches. If fn returns an error, the scenario panics rather than return the
+// wrong response, so the failure surfaces in the test that triggered it.
+func (s *Scenario) OnRequest(fn func(*http.Request) error) *Scenario {
+	s.assertions = append(s.assertions, fn)
+	return s
+}
+
+// Calls returns the number of requests this scenario has responded to so far.
+func (s *Scenario) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// Reset zeroes the scenario's call count and replays its sequence from the
+// start, without tearing down httpmock or re-registering the responder.
+func (s *Scenario) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = 0
+}
+
+// Register installs the scenario as an httpmock responder and returns the
+// scenario so Calls/Reset can be used afterwards.
+func (s *Scenario) Register() *Scenario {
+	httpmock.RegisterRegexpResponder(s.method, s.pattern, func(req *http.Request) (*http.Response, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, assertion := range s.assertions {
+			if err := assertion(req); err != nil {
+				panic(fmt.Sprintf("mock scenario assertion failed for %s %s: %v", s.method, req.URL, err))
+			}
+		}
+
+		if len(s.steps) == 0 {
+			return nil, ErrResponseNotFound
+		}
+
+		idx := s.calls
+		if idx >= len(s.steps) {
+			idx = len(s.steps) - 1 // stick to the last configured step once the sequence is exhausted
+		}
+		s.calls++
+
+		step := s.steps[idx]
+
+		return httpmock.NewStringResponse(step.status, step.body), nil
+	})
+
+	return s
+}
+
+// AddMockedResponseWithLatency adds a mocked response that is returned only
+// after delay has elapsed, to exercise timeout and retry behavior that
+// depends on slow upstreams.
+func AddMockedResponseWithLatency(method string, url string, statusCode int, content string, delay time.Duration) {
+	responder := httpmock.NewStringResponder(statusCode, content).Delay(delay)
+	httpmock.RegisterResponder(method, url, responder)
+}
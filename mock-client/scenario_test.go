@@ -0,0 +1,85 @@
+package mock
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+var errAuthRequired = errors.New("missing Authorization header")
+
+func TestScenario_SequencedResponses(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	scenario := NewScenario(http.MethodGet, `^https://dummy\.com/items/\d+$`).
+		Respond(http.StatusInternalServerError, `{"ok":false}`).
+		Then(http.StatusOK, `{"ok":true}`).
+		Register()
+
+	resp, err := http.Get("https://dummy.com/items/1")
+	c.NoError(err)
+	c.Equal(http.StatusInternalServerError, resp.StatusCode)
+	c.NoError(resp.Body.Close())
+
+	resp, err = http.Get("https://dummy.com/items/2")
+	c.NoError(err)
+	c.Equal(http.StatusOK, resp.StatusCode)
+	c.NoError(resp.Body.Close())
+
+	// Sequence is exhausted: sticks to the last configured step.
+	resp, err = http.Get("https://dummy.com/items/3")
+	c.NoError(err)
+	c.Equal(http.StatusOK, resp.StatusCode)
+	c.NoError(resp.Body.Close())
+
+	c.Equal(3, scenario.Calls())
+
+	scenario.Reset()
+	c.Equal(0, scenario.Calls())
+}
+
+func TestScenario_OnRequestAssertionFailurePanics(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	NewScenario(http.MethodGet, `^https://dummy\.com/secure$`).
+		Respond(http.StatusOK, `{}`).
+		OnRequest(func(r *http.Request) error {
+			if r.Header.Get("Authorization") == "" {
+				return errAuthRequired
+			}
+			return nil
+		}).
+		Register()
+
+	c.Panics(func() {
+		_, _ = http.Get("https://dummy.com/secure")
+	})
+}
+
+func TestAddMockedResponseWithLatency(t *testing.T) {
+	c := require.New(t)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	AddMockedResponseWithLatency(http.MethodGet, "https://dummy.com/slow", http.StatusOK, `{}`, 20*time.Millisecond)
+
+	start := time.Now()
+	resp, err := http.Get("https://dummy.com/slow")
+	elapsed := time.Since(start)
+
+	c.NoError(err)
+	c.Equal(http.StatusOK, resp.StatusCode)
+	c.NoError(resp.Body.Close())
+	c.GreaterOrEqual(elapsed, 20*time.Millisecond)
+}
@@ -0,0 +1,45 @@
+// Package tiny_errors provides a small typed error used as the return type
+// for handlers and services across the module, carrying a stable code and
+// the HTTP status it should be reported as.
+package tiny_errors
+
+import "encoding/json"
+
+// Error is a typed, HTTP-status-aware error.
+type Error interface {
+	error
+	Code() string
+	HTTPStatus() int
+}
+
+type tinyError struct {
+	code       string
+	message    string
+	httpStatus int
+}
+
+// New returns an Error with the given code, message, and HTTP status.
+func New(code string, message string, httpStatus int) Error {
+	return &tinyError{code: code, message: message, httpStatus: httpStatus}
+}
+
+func (e *tinyError) Error() string {
+	return e.message
+}
+
+func (e *tinyError) Code() string {
+	return e.code
+}
+
+func (e *tinyError) HTTPStatus() int {
+	return e.httpStatus
+}
+
+// MarshalJSON renders the error as {"code", "message"} for the handler
+// package's JSON envelope.
+func (e *tinyError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{Code: e.code, Message: e.message})
+}